@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// olderThanThresh and unusedForThresh gate which candidates walkDir reports,
+// set up in main from the --older-than and --unused-for flags. Zero means
+// "no filter".
+var (
+	olderThanThresh time.Duration
+	unusedForThresh time.Duration
+)
+
+// parseAgeDuration parses a threshold like "14d" or "30d" (days), and also
+// accepts anything time.ParseDuration understands (e.g. "12h").
+func parseAgeDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// passesAgeFilters reports whether path satisfies both the --older-than and
+// --unused-for thresholds (either of which may be unset).
+func passesAgeFilters(path string) bool {
+	if olderThanThresh > 0 && !olderThanThreshold(path, olderThanThresh) {
+		return false
+	}
+	if unusedForThresh > 0 && !unusedForThreshold(path, unusedForThresh) {
+		return false
+	}
+	return true
+}
+
+// olderThanThreshold reports whether path's mtime is older than threshold.
+// This keeps shitclean from nuking the target/ of a project you compiled
+// 10 minutes ago.
+func olderThanThreshold(path string, threshold time.Duration) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) >= threshold
+}
+
+// unusedForThreshold reports whether nothing inside root has been accessed
+// within threshold, sampled via a bounded depth-2 scan that short-circuits
+// as soon as it finds a file newer than the threshold.
+func unusedForThreshold(root string, threshold time.Duration) bool {
+	const maxDepth = 2
+	cutoff := time.Now().Add(-threshold)
+
+	var scan func(dir string, depth int) bool
+	scan = func(dir string, depth int) bool {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return true
+		}
+		for _, e := range entries {
+			p := filepath.Join(dir, e.Name())
+			if e.IsDir() {
+				if depth < maxDepth && !scan(p, depth+1) {
+					return false
+				}
+				continue
+			}
+			at, err := fileAtime(p)
+			if err != nil {
+				continue
+			}
+			if at.After(cutoff) {
+				return false
+			}
+		}
+		return true
+	}
+	return scan(root, 0)
+}
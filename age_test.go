@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAgeDuration(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"14d", 14 * 24 * time.Hour, false},
+		{"0.5d", 12 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"not-a-duration", 0, true},
+	}
+	for _, c := range cases {
+		got, err := parseAgeDuration(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseAgeDuration(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			continue
+		}
+		if err == nil && got != c.want {
+			t.Errorf("parseAgeDuration(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
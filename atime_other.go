@@ -0,0 +1,19 @@
+//go:build !linux && !windows
+
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// fileAtime falls back to mtime on platforms where we don't have a
+// syscall.Stat_t field layout for atime (e.g. Darwin's differs from
+// Linux's).
+func fileAtime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
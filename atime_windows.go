@@ -0,0 +1,23 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileAtime returns path's last-access time, falling back to its mtime if
+// the underlying FileInfo doesn't expose one.
+func fileAtime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return info.ModTime(), nil
+	}
+	return time.Unix(0, d.LastAccessTime.Nanoseconds()), nil
+}
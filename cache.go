@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bufio"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cachedChild is a directory entry persisted alongside a cacheEntry, just
+// enough to re-drive walkDir's recursion without an os.ReadDir call.
+type cachedChild struct {
+	Name  string
+	IsDir bool
+}
+
+// cachedHit mirrors foundDir for gob encoding (foundDir's fields are
+// unexported and gob only encodes exported fields).
+type cachedHit struct {
+	Path string
+	Typ  string
+}
+
+// cachedWatch is the stat of a directory's own clean dir candidates at the
+// time it was cached: for every active detector whose required files
+// already matched this directory, one entry per literal (non-glob)
+// clean_dirs pattern (see activeDetector.watchDirs), whether or not it
+// currently qualifies as a hit. walkDir never recurses into a clean dir
+// (it's in skipDirs), so without this, a clean dir that was empty (or
+// didn't exist) when the parent was first cached would never be noticed
+// once it's populated or created, since that doesn't touch the parent
+// directory's own mtime.
+type cachedWatch struct {
+	Path     string
+	Exists   bool
+	Dev, Ino uint64
+	Mtime    int64
+	Size     int64
+}
+
+// cacheEntry is what diskCache persists for a single directory: the stat
+// fields it was last seen with, its children, any detector hits found in
+// it, and the clean-dir watches that guard against the staleness
+// described on cachedWatch, so an unchanged directory can be replayed
+// without another os.ReadDir call or detector run.
+type cacheEntry struct {
+	Dev, Ino uint64
+	Mtime    int64
+	Size     int64
+	Children []cachedChild
+	Hits     []cachedHit
+	Watches  []cachedWatch
+}
+
+// statWatch builds the cachedWatch for path as it stands right now, for
+// both storing a fresh entry and re-checking one at replay time.
+func statWatch(path string) cachedWatch {
+	st, err := statDir(path)
+	if err != nil {
+		return cachedWatch{Path: path}
+	}
+	return cachedWatch{Path: path, Exists: true, Dev: st.dev, Ino: st.ino, Mtime: st.mtime, Size: st.size}
+}
+
+// cacheFile is the on-disk gob layout: the entries plus the detector-set
+// signature they were produced under, so a cache built with one detector
+// config is never replayed against a different one (see detectorsSig).
+type cacheFile struct {
+	DetectorsSig string
+	Entries      map[string]cacheEntry
+}
+
+// diskCache is a gob-encoded, on-disk cache of walkDir's per-directory
+// results, keyed by the path walkDir visited each directory under (relative
+// when --dir is relative). It lets repeated runs over the same tree
+// skip os.ReadDir and the detector funcs for directories whose
+// (dev, ino, mtime) haven't changed since the last run.
+type diskCache struct {
+	mu sync.Mutex
+	// path is where the cache is persisted; detectorsSig is the signature
+	// (see detectorsSignature) the active detector set must still match
+	// for entries to stay valid, since a directory's own (dev, ino, mtime)
+	// doesn't change when e.g. a detector is disabled via .shitclean.toml
+	// or a user detector is added to ~/.config/shitclean/detectors.toml.
+	path         string
+	detectorsSig string
+	entries      map[string]cacheEntry
+	dirty        bool
+}
+
+// detectorsSignature identifies the active detector set: each active
+// detector's name plus, for the generic ones, enough of its matching rule
+// that editing a detector's required files/globs/clean dirs (not just
+// enabling/disabling it) changes the result too. active is the final,
+// already-disabled-filtered set (as returned by loadActiveDetectors);
+// generic carries the matching-rule detail for the non-custom entries in
+// it. Used to invalidate the whole on-disk cache at load time when it was
+// built under a different detector config than the current run's.
+func detectorsSignature(active []activeDetector, generic []Detector) string {
+	byName := make(map[string]Detector, len(generic))
+	for _, d := range generic {
+		byName[d.Name] = d
+	}
+
+	parts := make([]string, 0, len(active))
+	for _, a := range active {
+		rest := "custom"
+		if d, ok := byName[a.name]; ok {
+			rest = strings.Join([]string{
+				strings.Join(d.RequiredFiles, ","),
+				strings.Join(d.RequiredFileGlobs, ","),
+				strings.Join(d.CleanDirs, ","),
+				strconv.FormatBool(d.RequireNonEmpty),
+			}, "|")
+		}
+		parts = append(parts, a.name+"="+rest)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\x00")
+}
+
+// defaultCachePath returns ~/.cache/shitclean/finder.db (or the platform
+// equivalent via os.UserCacheDir).
+func defaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "shitclean", "finder.db")
+}
+
+// loadDiskCache reads path into memory, if it exists. A missing or corrupt
+// cache file is treated as an empty cache rather than an error. The loaded
+// entries are kept as-is; callers that are about to replay them against a
+// live run must call discardIfStale first (prune doesn't, since it only
+// needs the raw entry count to report and removes the file regardless).
+func loadDiskCache(path string) *diskCache {
+	c := &diskCache{path: path, entries: make(map[string]cacheEntry)}
+	if path == "" {
+		return c
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return c
+	}
+	defer f.Close()
+	dec := gob.NewDecoder(bufio.NewReader(f))
+	var cf cacheFile
+	if err := dec.Decode(&cf); err == nil {
+		c.detectorsSig = cf.DetectorsSig
+		c.entries = cf.Entries
+	}
+	return c
+}
+
+// discardIfStale drops every loaded entry if the cache was built under a
+// different detector set than detectorsSig. A directory's own
+// (dev, ino, mtime) doesn't change when a detector is disabled via
+// .shitclean.toml or a user detector is added to
+// ~/.config/shitclean/detectors.toml, so without this check a stale hit
+// (or a stale "no hits here") from before the config change would keep
+// being replayed until the directory itself is touched.
+func (c *diskCache) discardIfStale(detectorsSig string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.detectorsSig != detectorsSig && len(c.entries) > 0 {
+		c.entries = make(map[string]cacheEntry)
+		c.dirty = true
+	}
+	c.detectorsSig = detectorsSig
+}
+
+// save writes the cache back to disk if it was modified, via a temp file
+// plus rename so a crash mid-write can't corrupt the cache.
+func (c *diskCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path == "" || !c.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := gob.NewEncoder(w).Encode(cacheFile{DetectorsSig: c.detectorsSig, Entries: c.entries}); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// lookup returns the cached entry for path if it is still valid for the
+// given directory stat fields and every one of its cachedWatch entries
+// still matches the corresponding clean dir's current stat.
+func (c *diskCache) lookup(path string, st dirStat) (cacheEntry, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[path]
+	c.mu.Unlock()
+	if !ok || e.Dev != st.dev || e.Ino != st.ino || e.Mtime != st.mtime || e.Size != st.size {
+		return cacheEntry{}, false
+	}
+	// Stat syscalls happen outside the lock so concurrent walkDir
+	// goroutines aren't serialized on them.
+	for _, w := range e.Watches {
+		if statWatch(w.Path) != w {
+			return cacheEntry{}, false
+		}
+	}
+	return e, true
+}
+
+func (c *diskCache) store(path string, e cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = e
+	c.dirty = true
+}
+
+// prune discards every cached entry and removes the on-disk file, returning
+// how many entries were dropped.
+func (c *diskCache) prune() (int, error) {
+	c.mu.Lock()
+	n := len(c.entries)
+	c.entries = make(map[string]cacheEntry)
+	c.dirty = false
+	c.mu.Unlock()
+	if c.path == "" {
+		return n, nil
+	}
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDiskCacheSaveLoadRoundTrip stores an entry, saves the cache to disk,
+// and reloads it into a fresh diskCache, across two separate load cycles
+// as a real run would.
+func TestDiskCacheSaveLoadRoundTrip(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "finder.db")
+
+	c1 := loadDiskCache(cachePath)
+	entry := cacheEntry{Dev: 1, Ino: 2, Mtime: 3, Size: 4, Hits: []cachedHit{{Path: "/p/target", Typ: "cargo"}}}
+	c1.store("/p", entry)
+	if err := c1.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	c2 := loadDiskCache(cachePath)
+	got, ok := c2.lookup("/p", dirStat{dev: 1, ino: 2, mtime: 3, size: 4})
+	if !ok {
+		t.Fatalf("lookup after reload: not found")
+	}
+	if len(got.Hits) != 1 || got.Hits[0].Path != "/p/target" {
+		t.Errorf("lookup after reload returned %+v", got)
+	}
+}
+
+// TestDiskCacheLookupMissOnStatChange covers the basic invalidation path:
+// a changed (dev, ino, mtime, size) for the directory itself is a cache
+// miss.
+func TestDiskCacheLookupMissOnStatChange(t *testing.T) {
+	c := loadDiskCache("")
+	c.store("/p", cacheEntry{Dev: 1, Ino: 2, Mtime: 3, Size: 4})
+
+	if _, ok := c.lookup("/p", dirStat{dev: 1, ino: 2, mtime: 3, size: 4}); !ok {
+		t.Fatalf("lookup with unchanged stat should hit")
+	}
+	if _, ok := c.lookup("/p", dirStat{dev: 1, ino: 2, mtime: 999, size: 4}); ok {
+		t.Errorf("lookup with changed mtime should miss")
+	}
+	if _, ok := c.lookup("/other", dirStat{dev: 1, ino: 2, mtime: 3, size: 4}); ok {
+		t.Errorf("lookup for an uncached path should miss")
+	}
+}
+
+// TestDiskCacheWatchesCatchCleanDirChange is a regression test for the bug
+// fixed alongside this test: a clean dir (e.g. an empty "target") that's
+// later populated doesn't change its parent directory's own mtime, so
+// lookup must also re-check each cachedWatch against the clean dir's
+// current stat, not just the parent's.
+func TestDiskCacheWatchesCatchCleanDirChange(t *testing.T) {
+	projDir := t.TempDir()
+	cleanDir := filepath.Join(projDir, "target")
+	if err := os.Mkdir(cleanDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	projStat, err := statDir(projDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watch := statWatch(cleanDir)
+
+	c := loadDiskCache("")
+	c.store(projDir, cacheEntry{
+		Dev: projStat.dev, Ino: projStat.ino, Mtime: projStat.mtime, Size: projStat.size,
+		Watches: []cachedWatch{watch},
+	})
+
+	if _, ok := c.lookup(projDir, projStat); !ok {
+		t.Fatalf("lookup should hit before the clean dir changes")
+	}
+
+	// Populate the previously-empty clean dir. This changes target's own
+	// mtime but must not change projDir's.
+	if err := os.WriteFile(filepath.Join(cleanDir, "out.bin"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	projStat2, err := statDir(projDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projStat2 != projStat {
+		t.Skipf("filesystem bumped the parent directory's own stat too (%v -> %v); nothing left to regression-test here", projStat, projStat2)
+	}
+
+	if _, ok := c.lookup(projDir, projStat2); ok {
+		t.Errorf("lookup should miss once a watched clean dir's contents changed, even though the parent directory's own stat didn't")
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//go:embed detectors_default.toml
+var defaultDetectorsTOML string
+
+// Detector is a declarative description of a build system: the files that
+// mark a directory as belonging to it, and the directories it's safe to
+// delete and regenerate. It's loaded from the embedded default config and
+// can be extended or overridden by a user config file, so niche toolchains
+// can be supported without recompiling shitclean.
+type Detector struct {
+	Name          string
+	RequiredFiles []string
+	// RequiredFileGlobs are alternate markers: the detector matches if any
+	// one of them is present, not all of them (e.g. gradle's build.gradle
+	// vs build.gradle.kts — a project only ever has one).
+	RequiredFileGlobs []string
+	CleanDirs         []string
+	RequireNonEmpty   bool
+}
+
+// activeDetector pairs a detector name with its matcher. Most come from
+// genericMatcher over a Detector loaded from config; a few (cmake, jai)
+// keep a hand-written detectorFunc because their matching logic doesn't
+// fit the generic shape.
+type activeDetector struct {
+	name  string
+	match detectorFunc
+	// watchDirs are det.CleanDirs' literal (non-glob) entries, for
+	// detectors built from a Detector config. walkDir stats these for
+	// every directory whose required files match this detector, whether
+	// or not they currently qualify as a hit, so the disk cache can tell
+	// when a clean dir's own contents changed (e.g. it was empty and is
+	// now populated) even though that doesn't touch the parent directory's
+	// mtime. Empty for the hand-written cmake/jai detectors and for glob
+	// clean_dirs like bazel's "bazel-*".
+	watchDirs []string
+	// requiredMatch reports whether this detector's required files/globs
+	// are present, independent of whether any clean dir currently
+	// qualifies as a hit. nil for the hand-written cmake/jai detectors,
+	// which don't populate watchDirs either.
+	requiredMatch func(entries []os.DirEntry, nameMap map[string]os.DirEntry) bool
+}
+
+// genericMatcher builds a detectorFunc that checks det's required files
+// and globs, then resolves the first matching, existing clean dir.
+func genericMatcher(det Detector) detectorFunc {
+	reqMatch := genericRequiredMatch(det)
+	return func(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
+		if !reqMatch(entries, nameMap) {
+			return false, ""
+		}
+		for _, pattern := range det.CleanDirs {
+			if p, ok := resolveCleanDir(path, entries, pattern, det.RequireNonEmpty); ok {
+				return true, p
+			}
+		}
+		return false, ""
+	}
+}
+
+// genericRequiredMatch builds the required-files/globs half of
+// genericMatcher on its own, so walkDir can check "is this det's project
+// type present here" without also needing a clean dir to currently
+// qualify as a hit (see activeDetector.requiredMatch).
+func genericRequiredMatch(det Detector) func(entries []os.DirEntry, nameMap map[string]os.DirEntry) bool {
+	return func(entries []os.DirEntry, nameMap map[string]os.DirEntry) bool {
+		for _, f := range det.RequiredFiles {
+			if !hasName(nameMap, f) {
+				return false
+			}
+		}
+		if len(det.RequiredFileGlobs) > 0 {
+			for _, g := range det.RequiredFileGlobs {
+				if anyGlobMatch(entries, g) {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	}
+}
+
+// anyGlobMatch reports whether any non-directory entry matches pattern.
+func anyGlobMatch(entries []os.DirEntry, pattern string) bool {
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, e.Name()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCleanDir resolves pattern against path's entries. A literal
+// pattern (no glob metacharacters) is joined directly onto path; a pattern
+// containing glob metacharacters (e.g. "bazel-*") is matched against child
+// directory names, since the generated directory's exact name isn't fixed.
+func resolveCleanDir(path string, entries []os.DirEntry, pattern string, requireNonEmpty bool) (string, bool) {
+	exists := func(p string) bool {
+		if requireNonEmpty {
+			return nonEmptyDir(p)
+		}
+		_, err := os.Stat(p)
+		return err == nil
+	}
+
+	if !strings.ContainsAny(pattern, "*?[") {
+		p := filepath.Join(path, filepath.FromSlash(pattern))
+		if exists(p) {
+			return p, true
+		}
+		return "", false
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, e.Name()); ok {
+			p := filepath.Join(path, e.Name())
+			if exists(p) {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// literalCleanDirs filters cleanDirs down to the entries with no glob
+// metacharacters, for activeDetector.watchDirs: those are the ones whose
+// path relative to a project directory is fixed, so the disk cache can
+// watch them by name without having to re-list the project directory.
+func literalCleanDirs(cleanDirs []string) []string {
+	var out []string
+	for _, c := range cleanDirs {
+		if !strings.ContainsAny(c, "*?[") {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// customDetectors are the detectors that keep a hand-written detectorFunc
+// because their logic doesn't fit the generic Detector shape: CMake needs
+// both CMakeCache.txt and a non-empty CMakeFiles to report the project
+// root itself (not a subdirectory), and Jai identifies projects by a
+// "*.jai file anywhere in the directory" scan rather than a fixed name.
+var customDetectors = map[string]detectorFunc{
+	"cmake": detectCMake,
+	"jai":   detectJai,
+}
+
+// loadActiveDetectors builds the full detector set for a run: the embedded
+// defaults, merged with an optional user config, minus anything disabled
+// by a .shitclean.toml found walking up from startDir. It also returns the
+// generic Detector configs alone (without the custom cmake/jai detectors),
+// so the caller can derive skipDirs from their clean dirs.
+func loadActiveDetectors(startDir string) ([]activeDetector, []Detector, error) {
+	dets, err := parseDetectorsTOML(defaultDetectorsTOML)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing built-in detector config: %w", err)
+	}
+
+	if path := userDetectorsPath(); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			userDets, err := parseDetectorsTOML(string(data))
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+			dets = mergeDetectors(dets, userDets)
+		}
+	}
+
+	disabled := findDisabledDetectors(startDir)
+
+	var kept []Detector
+	active := make([]activeDetector, 0, len(dets)+len(customDetectors))
+	for _, det := range dets {
+		if disabled[det.Name] {
+			continue
+		}
+		kept = append(kept, det)
+		active = append(active, activeDetector{
+			name:          det.Name,
+			match:         genericMatcher(det),
+			watchDirs:     literalCleanDirs(det.CleanDirs),
+			requiredMatch: genericRequiredMatch(det),
+		})
+	}
+	for name, fn := range customDetectors {
+		if disabled[name] {
+			continue
+		}
+		active = append(active, activeDetector{name: name, match: fn})
+	}
+	return active, kept, nil
+}
+
+// userDetectorsPath returns ~/.config/shitclean/detectors.toml (or the
+// platform equivalent via os.UserConfigDir).
+func userDetectorsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "shitclean", "detectors.toml")
+}
+
+// mergeDetectors overlays override onto base: a detector with the same
+// name replaces the base one, anything new is appended.
+func mergeDetectors(base, override []Detector) []Detector {
+	byName := make(map[string]int, len(base))
+	out := make([]Detector, len(base))
+	copy(out, base)
+	for i, d := range out {
+		byName[d.Name] = i
+	}
+	for _, d := range override {
+		if i, ok := byName[d.Name]; ok {
+			out[i] = d
+		} else {
+			byName[d.Name] = len(out)
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// buildSkipDirs derives the set of directory names walkDir should never
+// descend into: a handful of editor/VCS directories that aren't any
+// detector's output, plus every literal clean dir any active detector would
+// otherwise report, so the walker doesn't waste time recursing into build
+// output it's just going to report anyway. Glob clean_dirs such as
+// "bazel-*" are not added, so the walker still descends into those.
+func buildSkipDirs(dets []Detector) map[string]bool {
+	skip := map[string]bool{
+		".git":    true,
+		".idea":   true,
+		".vscode": true,
+	}
+	for _, det := range dets {
+		for _, c := range det.CleanDirs {
+			if !strings.ContainsAny(c, "*?[") {
+				skip[filepath.FromSlash(c)] = true
+			}
+		}
+	}
+	skip["CMakeFiles"] = true
+	return skip
+}
+
+//
+// minimal TOML subset parser
+//
+// Supports exactly what detectors.toml needs: array-of-tables
+// ([[detector]]), string/bool/string-array values, and # comments. Good
+// enough for a small, hand-authored config file without pulling in a TOML
+// dependency.
+//
+
+func parseDetectorsTOML(data string) ([]Detector, error) {
+	var result []Detector
+	var cur *Detector
+
+	for i, raw := range strings.Split(data, "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "[[detector]]" {
+			if cur != nil {
+				result = append(result, *cur)
+			}
+			cur = &Detector{RequireNonEmpty: true}
+			continue
+		}
+		if cur == nil {
+			return nil, fmt.Errorf("line %d: value outside a [[detector]] table", lineNo)
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+
+		switch key {
+		case "name":
+			cur.Name = unquoteTOMLString(val)
+		case "required_files":
+			cur.RequiredFiles = parseTOMLStringArray(val)
+		case "required_file_globs":
+			cur.RequiredFileGlobs = parseTOMLStringArray(val)
+		case "clean_dirs":
+			cur.CleanDirs = parseTOMLStringArray(val)
+		case "require_non_empty":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid bool %q", lineNo, val)
+			}
+			cur.RequireNonEmpty = b
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", lineNo, key)
+		}
+	}
+	if cur != nil {
+		result = append(result, *cur)
+	}
+	return result, nil
+}
+
+func unquoteTOMLString(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseTOMLStringArray(s string) []string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimSuffix(s, "]")
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = unquoteTOMLString(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+//
+// per-project config (.shitclean.toml)
+//
+
+// findDisabledDetectors walks up from startDir looking for a .shitclean.toml
+// with a top-level `disabled = ["gradle", "maven"]` entry, and returns the
+// set of detector names it names. The closest one found wins; parents are
+// not consulted once one is found.
+func findDisabledDetectors(startDir string) map[string]bool {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil
+	}
+	for {
+		path := filepath.Join(dir, ".shitclean.toml")
+		if data, err := os.ReadFile(path); err == nil {
+			return parseDisabledTOML(string(data))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+func parseDisabledTOML(data string) map[string]bool {
+	disabled := make(map[string]bool)
+	for _, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) != "disabled" {
+			continue
+		}
+		for _, name := range parseTOMLStringArray(strings.TrimSpace(val)) {
+			disabled[name] = true
+		}
+	}
+	return disabled
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDetectorsTOML(t *testing.T) {
+	data := `
+# a comment
+[[detector]]
+name = "gradle"
+required_file_globs = ["build.gradle", "build.gradle.kts"]
+clean_dirs = ["build"]
+
+[[detector]]
+name = "cargo"
+required_files = ["Cargo.toml"]
+clean_dirs = ["target"]
+require_non_empty = false
+`
+	dets, err := parseDetectorsTOML(data)
+	if err != nil {
+		t.Fatalf("parseDetectorsTOML: %v", err)
+	}
+	if len(dets) != 2 {
+		t.Fatalf("got %d detectors, want 2", len(dets))
+	}
+	if dets[0].Name != "gradle" || len(dets[0].RequiredFileGlobs) != 2 {
+		t.Errorf("gradle detector parsed wrong: %+v", dets[0])
+	}
+	if dets[1].Name != "cargo" || dets[1].RequireNonEmpty {
+		t.Errorf("cargo detector parsed wrong: %+v", dets[1])
+	}
+	if !dets[0].RequireNonEmpty {
+		t.Errorf("default require_non_empty should be true, got false")
+	}
+}
+
+func TestParseDetectorsTOMLErrors(t *testing.T) {
+	cases := []string{
+		"name = \"orphan\"",     // value outside [[detector]]
+		"[[detector]]\nfoo bar", // missing '='
+	}
+	for _, data := range cases {
+		if _, err := parseDetectorsTOML(data); err == nil {
+			t.Errorf("parseDetectorsTOML(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestMergeDetectors(t *testing.T) {
+	base := []Detector{
+		{Name: "cargo", CleanDirs: []string{"target"}},
+		{Name: "node", CleanDirs: []string{"node_modules"}},
+	}
+	override := []Detector{
+		{Name: "node", CleanDirs: []string{"node_modules", ".pnpm-store"}},
+		{Name: "zig", CleanDirs: []string{"zig-cache"}},
+	}
+	merged := mergeDetectors(base, override)
+	if len(merged) != 3 {
+		t.Fatalf("got %d detectors, want 3", len(merged))
+	}
+	byName := make(map[string]Detector, len(merged))
+	for _, d := range merged {
+		byName[d.Name] = d
+	}
+	if len(byName["node"].CleanDirs) != 2 {
+		t.Errorf("node override didn't replace base: %+v", byName["node"])
+	}
+	if _, ok := byName["zig"]; !ok {
+		t.Errorf("new detector from override missing")
+	}
+	if _, ok := byName["cargo"]; !ok {
+		t.Errorf("untouched base detector dropped")
+	}
+}
+
+// TestRequiredFileGlobsORSemantics guards the regression fixed in
+// 2ebb9a4: a detector listing more than one required_file_globs pattern
+// must match when ANY one of them is present, not all of them.
+func TestRequiredFileGlobsORSemantics(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "build.gradle"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "build"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "build", "out.jar"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nameMap := make(map[string]os.DirEntry, len(entries))
+	for _, e := range entries {
+		nameMap[e.Name()] = e
+	}
+
+	det := Detector{
+		Name:              "gradle",
+		RequiredFileGlobs: []string{"build.gradle", "build.gradle.kts"},
+		CleanDirs:         []string{"build"},
+	}
+	match := genericMatcher(det)
+	found, cleanDir := match(dir, entries, nameMap)
+	if !found {
+		t.Fatalf("gradle detector didn't match with only build.gradle present")
+	}
+	if cleanDir != filepath.Join(dir, "build") {
+		t.Errorf("cleanDir = %q, want %q", cleanDir, filepath.Join(dir, "build"))
+	}
+}
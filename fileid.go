@@ -0,0 +1,23 @@
+package main
+
+import "sync"
+
+// fileid uniquely identifies a physical directory on a given volume,
+// independent of the path used to reach it. It's used to catch bind
+// mounts, hardlinked directory trees, and to dedup candidates that
+// resolve to the same directory through different paths.
+type fileid struct {
+	dev, ino uint64
+}
+
+// visitedDirs is the set of directory fileids walkDir has already
+// descended into, so a single physical directory tree reachable through
+// multiple paths (bind mounts, hardlinks) is only walked once.
+var visitedDirs sync.Map
+
+// markVisited records id as visited and reports whether it had already
+// been visited before this call.
+func markVisited(id fileid) (alreadyVisited bool) {
+	_, loaded := visitedDirs.LoadOrStore(id, struct{}{})
+	return loaded
+}
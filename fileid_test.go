@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestDedupByFileid(t *testing.T) {
+	found := []foundDir{
+		{path: "/a/target", id: fileid{dev: 1, ino: 1}},
+		{path: "/b/target", id: fileid{dev: 1, ino: 1}}, // same physical dir as above
+		{path: "/c/target", id: fileid{dev: 1, ino: 2}},
+		{path: "/d/target"}, // fileid couldn't be determined, always kept
+		{path: "/e/target"}, // same zero fileid, still kept
+	}
+	out := dedupByFileid(found)
+	if len(out) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(out), out)
+	}
+	paths := make(map[string]bool, len(out))
+	for _, fd := range out {
+		paths[fd.path] = true
+	}
+	for _, want := range []string{"/a/target", "/c/target", "/d/target", "/e/target"} {
+		if !paths[want] {
+			t.Errorf("expected %q to survive dedup, got %+v", want, out)
+		}
+	}
+	if paths["/b/target"] {
+		t.Errorf("expected /b/target to be dropped as a duplicate of /a/target")
+	}
+}
+
+func TestMarkVisited(t *testing.T) {
+	id := fileid{dev: 12345, ino: 67890}
+	if markVisited(id) {
+		t.Fatalf("first markVisited(%v) reported already visited", id)
+	}
+	if !markVisited(id) {
+		t.Fatalf("second markVisited(%v) reported not yet visited", id)
+	}
+}
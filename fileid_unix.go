@@ -0,0 +1,21 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// loadFileid stats path and extracts its (dev, ino) pair.
+func loadFileid(path string) (fileid, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileid{}, err
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileid{}, nil
+	}
+	return fileid{dev: uint64(st.Dev), ino: uint64(st.Ino)}, nil
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package main
+
+import "syscall"
+
+// loadFileid opens path and reads its volume serial number plus file index
+// via GetFileInformationByHandle, which together play the role dev/ino
+// play on Unix.
+func loadFileid(path string) (fileid, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return fileid{}, err
+	}
+	h, err := syscall.CreateFile(p, 0, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return fileid{}, err
+	}
+	defer syscall.CloseHandle(h)
+
+	var info syscall.ByHandleFileInformation
+	if err := syscall.GetFileInformationByHandle(h, &info); err != nil {
+		return fileid{}, err
+	}
+	return fileid{
+		dev: uint64(info.VolumeSerialNumber),
+		ino: uint64(info.FileIndexHigh)<<32 | uint64(info.FileIndexLow),
+	}, nil
+}
@@ -19,20 +19,14 @@ const (
 
 var (
 	dirCount uint64
-	skipDirs = map[string]bool{
-		"target":       true,
-		"node_modules": true,
-		"CMakeFiles":   true,
-		"build":        true,
-		"bin":          true,
-		"obj":          true,
-		"dist":         true,
-		".gradle":      true,
-		".idea":        true,
-		".vscode":      true,
-		".dub":         true,
-		".build":       true,
-	}
+	// skipDirs is populated from the active detector set in main (see
+	// buildSkipDirs) so the walker never descends into a directory it
+	// would just turn around and report as a clean target.
+	skipDirs map[string]bool
+	// activeDetectors is the detector set for this run: the embedded
+	// defaults merged with any user/project config, set up in main via
+	// loadActiveDetectors before the walk starts.
+	activeDetectors []activeDetector
 )
 
 // dirEntriesCache caches the results of os.ReadDir for already read paths
@@ -54,39 +48,6 @@ func readDirCached(path string) ([]os.DirEntry, error) {
 // return (found, directoryToDelete)
 type detectorFunc func(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string)
 
-var detectors = map[string]detectorFunc{
-	"cargo":    detectCargo,
-	"node":     detectNode,
-	"cmake":    detectCMake,
-	"maven":    detectMaven,
-	"gradle":   detectGradle,
-	"dotnet":   detectDotNet,
-	"python":   detectPython,
-	"d":        detectD,
-	"jai":      detectJai,
-	"swiftpm":  detectSwiftPM,
-	"qobs":     detectQobs,
-	"bazel":    detectBazel,
-	"meson":    detectMeson,
-	"ninja":    detectNinja,
-	"sbt":      detectSBT,
-	"cabal":    detectCabal,
-	"stack":    detectStack,
-	"composer": detectComposer,
-	"bundler":  detectBundler,
-	"pnpm":     detectPNPM,
-	"bun":      detectBun,
-	"expo":     detectExpo,
-	"next":     detectNextJS,
-	"angular":  detectAngular,
-	"unreal":   detectUnreal,
-	"unity":    detectUnity,
-	"android":  detectAndroid,
-	"flutter":  detectFlutter,
-	"mix":      detectMix,
-	"rebar":    detectRebar,
-}
-
 //
 // helpers
 //
@@ -114,41 +75,12 @@ func nonEmptyDir(path string) bool {
 }
 
 //
-// detectors
+// custom detectors
+//
+// These two keep a hand-written detectorFunc (see config.go's
+// customDetectors) because their matching logic doesn't fit the generic
+// Detector shape.
 //
-
-func detectCargo(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "Cargo.toml") {
-		return false, ""
-	}
-	target := filepath.Join(path, "target")
-	if nonEmptyDir(target) {
-		return true, target
-	}
-	return false, ""
-}
-
-func detectQobs(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "Qobs.toml") {
-		return false, ""
-	}
-	build := filepath.Join(path, "build")
-	if nonEmptyDir(build) {
-		return true, build
-	}
-	return false, ""
-}
-
-func detectNode(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "package.json") {
-		return false, ""
-	}
-	nodeModules := filepath.Join(path, "node_modules")
-	if nonEmptyDir(nodeModules) {
-		return true, nodeModules
-	}
-	return false, ""
-}
 
 func detectCMake(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
 	if !hasName(nameMap, "CMakeCache.txt") || !hasName(nameMap, "CMakeFiles") {
@@ -161,84 +93,6 @@ func detectCMake(path string, entries []os.DirEntry, nameMap map[string]os.DirEn
 	return false, ""
 }
 
-func detectMaven(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "pom.xml") {
-		return false, ""
-	}
-	target := filepath.Join(path, "target")
-	if nonEmptyDir(target) {
-		return true, target
-	}
-	return false, ""
-}
-
-func detectGradle(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	// build.gradle or build.gradle.kts
-	if !hasName(nameMap, "build.gradle") && !hasName(nameMap, "build.gradle.kts") {
-		return false, ""
-	}
-	buildDir := filepath.Join(path, "build")
-	if nonEmptyDir(buildDir) {
-		return true, buildDir
-	}
-	return false, ""
-}
-
-func detectDotNet(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	// check bin or obj dir presence and non-empty, and .csproj or .sln exists in current dir
-	foundProj := false
-	for _, e := range entries {
-		if !e.IsDir() {
-			l := strings.ToLower(e.Name())
-			if strings.HasSuffix(l, ".csproj") || strings.HasSuffix(l, ".sln") {
-				foundProj = true
-				break
-			}
-		}
-	}
-	if !foundProj {
-		return false, ""
-	}
-	// prefer bin then obj
-	binDir := filepath.Join(path, "bin")
-	if nonEmptyDir(binDir) {
-		return true, binDir
-	}
-	objDir := filepath.Join(path, "obj")
-	if nonEmptyDir(objDir) {
-		return true, objDir
-	}
-	return false, ""
-}
-
-func detectPython(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	// need either setup.py or pyproject.toml
-	if !hasName(nameMap, "setup.py") && !hasName(nameMap, "pyproject.toml") {
-		return false, ""
-	}
-	buildDir := filepath.Join(path, "build")
-	if nonEmptyDir(buildDir) {
-		return true, buildDir
-	}
-	distDir := filepath.Join(path, "dist")
-	if nonEmptyDir(distDir) {
-		return true, distDir
-	}
-	return false, ""
-}
-
-func detectD(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	// need dub.json or dub.sdl and a .dub directory non-empty
-	if !hasName(nameMap, "dub.json") && !hasName(nameMap, "dub.sdl") {
-		return false, ""
-	}
-	dubDir := filepath.Join(path, ".dub")
-	if nonEmptyDir(dubDir) {
-		return true, dubDir
-	}
-	return false, ""
-}
-
 func detectJai(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
 	// check for any *.jai file in current dir
 	if !anySuffix(entries, ".jai") {
@@ -253,256 +107,30 @@ func detectJai(path string, entries []os.DirEntry, nameMap map[string]os.DirEntr
 	}
 	return false, ""
 }
+func walkDir(path string, depth int, sem chan struct{}, wg *sync.WaitGroup, results chan<- foundDir, cache *diskCache) {
+	defer wg.Done()
 
-func detectSwiftPM(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "Package.swift") {
-		return false, ""
-	}
-	p := filepath.Join(path, ".build")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectBazel(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "WORKSPACE") && !hasName(nameMap, "BUILD") {
-		return false, ""
-	}
-	for _, e := range entries {
-		if strings.HasPrefix(e.Name(), "bazel-") && nonEmptyDir(filepath.Join(path, e.Name())) {
-			return true, filepath.Join(path, e.Name())
-		}
-	}
-	return false, ""
-}
-
-func detectMeson(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "meson.build") {
-		return false, ""
-	}
-	for _, d := range []string{"build", "_build"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectNinja(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "build.ninja") {
-		return false, ""
-	}
-	p := filepath.Join(path, "build")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectSBT(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "build.sbt") {
-		return false, ""
-	}
-	p := filepath.Join(path, "target")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectCabal(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	for name := range nameMap {
-		if strings.HasSuffix(name, ".cabal") {
-			for _, d := range []string{"dist-newstyle", "dist"} {
-				p := filepath.Join(path, d)
-				if nonEmptyDir(p) {
-					return true, p
-				}
-			}
-		}
-	}
-	return false, ""
-}
-
-func detectStack(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "stack.yaml") {
-		return false, ""
-	}
-	p := filepath.Join(path, ".stack-work")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectComposer(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "composer.json") {
-		return false, ""
-	}
-	p := filepath.Join(path, "vendor")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectBundler(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "Gemfile") {
-		return false, ""
-	}
-	p := filepath.Join(path, "vendor", "bundle")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectPNPM(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "pnpm-lock.yaml") {
-		return false, ""
-	}
-	for _, d := range []string{"node_modules", ".pnpm-store"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectBun(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "bun.lockb") {
-		return false, ""
-	}
-	for _, d := range []string{"node_modules", ".bun"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectExpo(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "app.json") && !hasName(nameMap, "app.config.js") {
-		return false, ""
-	}
-	for _, d := range []string{".expo", ".expo-shared"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectNextJS(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "package.json") {
-		return false, ""
-	}
-	p := filepath.Join(path, ".next")
-	if nonEmptyDir(p) {
-		return true, p
+	if depth >= maxRecursionDepth {
+		return
 	}
-	return false, ""
-}
 
-func detectAngular(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "angular.json") {
-		return false, ""
-	}
-	p := filepath.Join(path, "dist")
-	if nonEmptyDir(p) {
-		return true, p
+	if id, err := loadFileid(path); err == nil && markVisited(id) {
+		return
 	}
-	return false, ""
-}
 
-func detectUnreal(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	for name := range nameMap {
-		if strings.HasSuffix(name, ".uproject") {
-			for _, d := range []string{"Intermediate", "Saved", "Binaries"} {
-				p := filepath.Join(path, d)
-				if nonEmptyDir(p) {
-					return true, p
-				}
+	var st dirStat
+	if cache != nil {
+		if s, err := statDir(path); err == nil {
+			st = s
+			if ce, ok := cache.lookup(path, st); ok {
+				replayCachedDir(path, depth, ce, sem, wg, results, cache)
+				atomic.AddUint64(&dirCount, 1)
+				return
 			}
+		} else {
+			cache = nil
 		}
 	}
-	return false, ""
-}
-
-func detectUnity(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "ProjectSettings") {
-		return false, ""
-	}
-	for _, d := range []string{"Library", "Temp", "Logs", "obj"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectAndroid(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "AndroidManifest.xml") {
-		return false, ""
-	}
-	p := filepath.Join(path, "build")
-	if nonEmptyDir(p) {
-		return true, p
-	}
-	return false, ""
-}
-
-func detectFlutter(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "pubspec.yaml") {
-		return false, ""
-	}
-	for _, d := range []string{"build", ".dart_tool"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectMix(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "mix.exs") {
-		return false, ""
-	}
-	for _, d := range []string{"_build", "deps"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func detectRebar(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) (bool, string) {
-	if !hasName(nameMap, "rebar.config") {
-		return false, ""
-	}
-	for _, d := range []string{"_build", "deps"} {
-		p := filepath.Join(path, d)
-		if nonEmptyDir(p) {
-			return true, p
-		}
-	}
-	return false, ""
-}
-
-func walkDir(path string, depth int, sem chan struct{}, wg *sync.WaitGroup, results chan<- foundDir) {
-	defer wg.Done()
-
-	if depth >= maxRecursionDepth {
-		return
-	}
 
 	entries, err := readDirCached(path)
 	if err != nil {
@@ -515,15 +143,24 @@ func walkDir(path string, depth int, sem chan struct{}, wg *sync.WaitGroup, resu
 		nameMap[e.Name()] = e
 	}
 
-	// run detectors using entries+map
-	for typ, detector := range detectors {
-		if found, dirPath := detector(path, entries, nameMap); found {
-			results <- foundDir{path: dirPath, typ: typ}
+	var hits []cachedHit
+	// run the active detector set against entries+map
+	for _, det := range activeDetectors {
+		if found, dirPath := det.match(path, entries, nameMap); found {
+			hits = append(hits, cachedHit{Path: dirPath, Typ: det.name})
+			if !passesAgeFilters(dirPath) {
+				continue
+			}
+			id, _ := loadFileid(dirPath)
+			results <- foundDir{path: dirPath, typ: det.name, id: id}
 		}
 	}
 
+	children := make([]cachedChild, 0, len(entries))
+
 	// iterate/skip child directories
 	for _, entry := range entries {
+		children = append(children, cachedChild{Name: entry.Name(), IsDir: entry.IsDir()})
 		if !entry.IsDir() {
 			continue
 		}
@@ -548,27 +185,164 @@ func walkDir(path string, depth int, sem chan struct{}, wg *sync.WaitGroup, resu
 		case sem <- struct{}{}:
 			go func(p string) {
 				// run walker
-				walkDir(p, depth+1, sem, wg, results)
+				walkDir(p, depth+1, sem, wg, results, cache)
 				<-sem
 			}(fullPath)
 		default:
 			// no semaphore slot available, run in this goroutine
-			walkDir(fullPath, depth+1, sem, wg, results)
+			walkDir(fullPath, depth+1, sem, wg, results, cache)
 		}
 	}
 
+	if cache != nil {
+		cache.store(path, cacheEntry{
+			Dev:      st.dev,
+			Ino:      st.ino,
+			Mtime:    st.mtime,
+			Size:     st.size,
+			Children: children,
+			Hits:     hits,
+			Watches:  cleanDirWatches(path, entries, nameMap),
+		})
+	}
+
 	atomic.AddUint64(&dirCount, 1)
 }
 
+// cleanDirWatches builds the cachedWatch list for path: one entry per
+// literal clean_dirs pattern of every active detector whose required
+// files/globs already match here, whether or not that clean dir currently
+// qualifies as a hit. See cachedWatch for why this is needed alongside
+// path's own (dev, ino, mtime).
+func cleanDirWatches(path string, entries []os.DirEntry, nameMap map[string]os.DirEntry) []cachedWatch {
+	var watches []cachedWatch
+	for _, det := range activeDetectors {
+		if det.requiredMatch == nil || len(det.watchDirs) == 0 {
+			continue
+		}
+		if !det.requiredMatch(entries, nameMap) {
+			continue
+		}
+		for _, w := range det.watchDirs {
+			watches = append(watches, statWatch(filepath.Join(path, filepath.FromSlash(w))))
+		}
+	}
+	return watches
+}
+
+// replayCachedDir re-drives walkDir's recursion and detector hits from a
+// cache entry, without calling os.ReadDir or any detector func.
+func replayCachedDir(path string, depth int, ce cacheEntry, sem chan struct{}, wg *sync.WaitGroup, results chan<- foundDir, cache *diskCache) {
+	for _, hit := range ce.Hits {
+		if !passesAgeFilters(hit.Path) {
+			continue
+		}
+		id, _ := loadFileid(hit.Path)
+		results <- foundDir{path: hit.Path, typ: hit.Typ, id: id}
+	}
+
+	for _, child := range ce.Children {
+		if !child.IsDir || skipDirs[child.Name] {
+			continue
+		}
+		fullPath := filepath.Join(path, child.Name)
+		info, err := os.Lstat(fullPath)
+		if err != nil || info.Mode()&os.ModeSymlink != 0 {
+			continue
+		}
+
+		wg.Add(1)
+		select {
+		case sem <- struct{}{}:
+			go func(p string) {
+				walkDir(p, depth+1, sem, wg, results, cache)
+				<-sem
+			}(fullPath)
+		default:
+			walkDir(fullPath, depth+1, sem, wg, results, cache)
+		}
+	}
+}
+
 type foundDir struct {
 	path string
 	typ  string
+	id   fileid
+	size int64
+}
+
+// dedupByFileid drops candidates that resolve to the same physical
+// directory as one already kept, so a single directory reached through
+// multiple paths (bind mounts, hardlinks) isn't deleted/prompted for twice.
+// Candidates whose fileid couldn't be determined are always kept.
+func dedupByFileid(found []foundDir) []foundDir {
+	seen := make(map[fileid]bool, len(found))
+	out := found[:0]
+	for _, fd := range found {
+		if fd.id == (fileid{}) {
+			out = append(out, fd)
+			continue
+		}
+		if seen[fd.id] {
+			continue
+		}
+		seen[fd.id] = true
+		out = append(out, fd)
+	}
+	return out
 }
 
 //
 // tui
 //
 
+// sequentialConfirmDelete drives the original one-at-a-time y/n prompt
+// flow, for non-TTY stdin, --no-tui, or when the interactive list isn't
+// available on this platform.
+func sequentialConfirmDelete(found []foundDir, deleter Deleter) int {
+	deleted := 0
+	for i, fd := range found {
+		if confirm(fmt.Sprintf("(%d/%d) remove %s directory at %s (%s) (y/n)?", i+1, len(found), fd.typ, fd.path, humanSize(fd.size))) {
+			if err := deleter.Delete(fd.path); err == nil {
+				deleted++
+			} else {
+				fmt.Printf("Error removing %s: %v\n", fd.path, err)
+			}
+		}
+	}
+	return deleted
+}
+
+// parallelDelete removes each of found concurrently, bounded by sem. A
+// true progress bar isn't practical over concurrently-written plain
+// stdout, so each directory instead gets a start/done line, which is
+// still useful feedback while a multi-GB node_modules is being removed.
+func parallelDelete(found []foundDir, sem chan struct{}, deleter Deleter) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	deleted := 0
+	for _, fd := range found {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(fd foundDir) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fmt.Printf("Removing %s (%s)...\n", fd.path, humanSize(fd.size))
+			err := deleter.Delete(fd.path)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				fmt.Printf("Error removing %s: %v\n", fd.path, err)
+				return
+			}
+			deleted++
+			fmt.Printf("Removed %s\n", fd.path)
+		}(fd)
+	}
+	wg.Wait()
+	return deleted
+}
+
 func confirm(prompt string) bool {
 	fmt.Print(prompt + " ")
 	scanner := bufio.NewScanner(os.Stdin)
@@ -596,13 +370,61 @@ func printProgress(stopChan chan struct{}, wg *sync.WaitGroup) {
 
 func main() {
 	startDir := flag.String("dir", ".", "directory to start cleaning")
+	noCache := flag.Bool("no-cache", false, "don't read or write the on-disk finder cache")
+	pruneCache := flag.Bool("prune-cache", false, "discard the on-disk finder cache and exit")
+	minSizeFlag := flag.String("min-size", "", "hide candidates smaller than this (e.g. 500M, 2G)")
+	sortFlag := flag.String("sort", "", "order candidates by size, age, or path")
+	totalFlag := flag.Bool("total", false, "print cumulative reclaimable size before prompting")
+	olderThanFlag := flag.String("older-than", "", "only report candidates whose mtime is older than this (e.g. 14d)")
+	unusedForFlag := flag.String("unused-for", "", "only report candidates with no file accessed within this long (e.g. 30d)")
+	yesFlag := flag.Bool("yes", false, "delete every candidate without prompting")
+	noTUI := flag.Bool("no-tui", false, "use the line-oriented y/n prompts instead of the interactive list")
+	permanentFlag := flag.Bool("permanent", false, "delete permanently instead of moving to the trash")
+	noCrossFSTrash := flag.Bool("no-cross-fs-trash", false, "delete permanently instead of copying into the trash across filesystems")
 	flag.Parse()
 
+	var err error
+	if olderThanThresh, err = parseAgeDuration(*olderThanFlag); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if unusedForThresh, err = parseAgeDuration(*unusedForFlag); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cachePath := defaultCachePath()
+
+	if *pruneCache {
+		n, err := loadDiskCache(cachePath).prune()
+		if err != nil {
+			fmt.Printf("Error pruning cache: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Pruned %d cached directories.\n", n)
+		return
+	}
+
 	absPath, _ := filepath.Abs(*startDir)
+
+	active, generic, err := loadActiveDetectors(absPath)
+	if err != nil {
+		fmt.Printf("Error loading detector config: %v\n", err)
+		os.Exit(1)
+	}
+	activeDetectors = active
+	skipDirs = buildSkipDirs(generic)
+
 	if !confirm(fmt.Sprintf("This will recursively search build folders in %s. You will be prompted to delete each one. Are you sure (y/n)?", absPath)) {
 		return
 	}
 
+	var cache *diskCache
+	if !*noCache {
+		cache = loadDiskCache(cachePath)
+		cache.discardIfStale(detectorsSignature(active, generic))
+	}
+
 	sem := make(chan struct{}, concurrencyLimit)
 	var wg sync.WaitGroup
 	results := make(chan foundDir, 100)
@@ -614,7 +436,7 @@ func main() {
 
 	// start walker
 	wg.Add(1)
-	go walkDir(*startDir, 0, sem, &wg, results)
+	go walkDir(*startDir, 0, sem, &wg, results, cache)
 
 	// close results when done
 	go func() {
@@ -631,6 +453,14 @@ func main() {
 	close(stopProgress)
 	progressWg.Wait()
 
+	found = dedupByFileid(found)
+
+	if cache != nil {
+		if err := cache.save(); err != nil {
+			fmt.Printf("Warning: failed to save finder cache: %v\n", err)
+		}
+	}
+
 	totalDirs := atomic.LoadUint64(&dirCount)
 	fmt.Printf("\nProcessed %d directories, found %d candidates.\n", totalDirs, len(found))
 	if len(found) == 0 {
@@ -638,15 +468,56 @@ func main() {
 		return
 	}
 
-	deleted := 0
-	for i, fd := range found {
-		if confirm(fmt.Sprintf("(%d/%d) remove %s directory at %s (y/n)?", i+1, len(found), fd.typ, fd.path)) {
-			if err := os.RemoveAll(fd.path); err == nil {
-				deleted++
-			} else {
-				fmt.Printf("Error removing %s: %v\n", fd.path, err)
+	computeSizes(found, sem)
+
+	if *minSizeFlag != "" {
+		minSize, err := parseSize(*minSizeFlag)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		filtered := found[:0]
+		for _, fd := range found {
+			if fd.size >= minSize {
+				filtered = append(filtered, fd)
 			}
 		}
+		found = filtered
+	}
+
+	sortFound(found, *sortFlag)
+
+	var totalSize int64
+	for _, fd := range found {
+		totalSize += fd.size
+		fmt.Printf("Found %s (type: %s, size: %s)\n", fd.path, fd.typ, humanSize(fd.size))
+	}
+	if *totalFlag {
+		fmt.Printf("Total reclaimable: %s\n", humanSize(totalSize))
+	}
+	if len(found) == 0 {
+		fmt.Println("Nothing left after filtering.")
+		return
+	}
+
+	var deleter Deleter = TrashDeleter{NoCrossFS: *noCrossFSTrash}
+	if *permanentFlag {
+		deleter = RemoveAllDeleter{}
+	}
+
+	var deleted int
+	switch {
+	case *yesFlag:
+		deleted = parallelDelete(found, sem, deleter)
+	case !*noTUI:
+		selected, err := runInteractiveSelect(found)
+		if err == nil {
+			deleted = parallelDelete(selected, sem, deleter)
+		} else {
+			deleted = sequentialConfirmDelete(found, deleter)
+		}
+	default:
+		deleted = sequentialConfirmDelete(found, deleter)
 	}
 
 	if deleted > 0 {
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// computeSizes fills in each candidate's on-disk size by walking it,
+// running up to cap(sem) candidates at once so size computation doesn't
+// pile more concurrency on top of the walker than concurrencyLimit allows.
+func computeSizes(found []foundDir, sem chan struct{}) {
+	var wg sync.WaitGroup
+	for i := range found {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			found[i].size = dirSize(found[i].path)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// dirSize sums the apparent size of every non-directory entry under root;
+// symlinks are counted at their own size, not their target's.
+func dirSize(root string) int64 {
+	var size int64
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// humanSize formats n bytes as a short human-readable string, e.g. "4.2GiB".
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// parseSize parses a human size string like "500M" or "2G" into bytes. A
+// bare number (no suffix) is interpreted as bytes.
+func parseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	mult := int64(1)
+	numPart := s
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		mult = 1 << 10
+	case "M":
+		mult = 1 << 20
+	case "G":
+		mult = 1 << 30
+	case "T":
+		mult = 1 << 40
+	}
+	if mult != 1 {
+		numPart = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// sortFound orders found in place by "size" (largest first), "age" (oldest
+// mtime first), or "path". Any other value leaves the order untouched.
+func sortFound(found []foundDir, by string) {
+	switch by {
+	case "size":
+		sort.Slice(found, func(i, j int) bool { return found[i].size > found[j].size })
+	case "age":
+		type aged struct {
+			fd    foundDir
+			mtime int64
+		}
+		entries := make([]aged, len(found))
+		for i, fd := range found {
+			var mt int64
+			if info, err := os.Stat(fd.path); err == nil {
+				mt = info.ModTime().UnixNano()
+			}
+			entries[i] = aged{fd, mt}
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].mtime < entries[j].mtime })
+		for i, e := range entries {
+			found[i] = e.fd
+		}
+	case "path":
+		sort.Slice(found, func(i, j int) bool { return found[i].path < found[j].path })
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"500", 500, false},
+		{"1K", 1 << 10, false},
+		{"500M", 500 * (1 << 20), false},
+		{"2G", 2 * (1 << 30), false},
+		{"1T", 1 << 40, false},
+		{"bogus", 0, true},
+	}
+	for _, c := range cases {
+		got, err := parseSize(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseSize(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			continue
+		}
+		if err == nil && got != c.want {
+			t.Errorf("parseSize(%q) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestHumanSize(t *testing.T) {
+	cases := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{1 << 30, "1.0GiB"},
+	}
+	for _, c := range cases {
+		if got := humanSize(c.in); got != c.want {
+			t.Errorf("humanSize(%d) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSortFoundBySize(t *testing.T) {
+	found := []foundDir{
+		{path: "a", size: 10},
+		{path: "b", size: 100},
+		{path: "c", size: 1},
+	}
+	sortFound(found, "size")
+	want := []string{"b", "a", "c"}
+	for i, w := range want {
+		if found[i].path != w {
+			t.Errorf("sortFound by size: position %d = %q, want %q", i, found[i].path, w)
+		}
+	}
+}
+
+func TestSortFoundByPath(t *testing.T) {
+	found := []foundDir{
+		{path: "z"},
+		{path: "a"},
+		{path: "m"},
+	}
+	sortFound(found, "path")
+	want := []string{"a", "m", "z"}
+	for i, w := range want {
+		if found[i].path != w {
+			t.Errorf("sortFound by path: position %d = %q, want %q", i, found[i].path, w)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// dirStat is the platform-independent subset of directory metadata the
+// cache keys on to decide whether a directory has changed since it was
+// last visited.
+type dirStat struct {
+	dev, ino uint64
+	mtime    int64
+	size     int64
+}
+
+// statDir stats path and extracts dev/ino where the platform supports it.
+func statDir(path string) (dirStat, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return dirStat{}, err
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return dirStat{mtime: info.ModTime().UnixNano(), size: info.Size()}, nil
+	}
+	return dirStat{
+		dev:   uint64(st.Dev),
+		ino:   uint64(st.Ino),
+		mtime: info.ModTime().UnixNano(),
+		size:  info.Size(),
+	}, nil
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// dirStat is the platform-independent subset of directory metadata the
+// cache keys on to decide whether a directory has changed since it was
+// last visited.
+type dirStat struct {
+	dev, ino uint64
+	mtime    int64
+	size     int64
+}
+
+// statDir stats path. Windows has no stable dev/ino from os.FileInfo alone,
+// so cache entries on this platform key on mtime/size only.
+func statDir(path string) (dirStat, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return dirStat{}, err
+	}
+	return dirStat{mtime: info.ModTime().UnixNano(), size: info.Size()}, nil
+}
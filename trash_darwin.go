@@ -0,0 +1,25 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+)
+
+// moveToTrash asks Finder to move path to the Trash via AppleScript,
+// which handles cross-volume moves itself. noCrossFS is unused here: the
+// Finder trash isn't tied to a single filesystem the way XDG trash is.
+func moveToTrash(path string, noCrossFS bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf(`tell application "Finder" to delete POSIX file %q`, abs)
+	out, err := exec.Command("osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("osascript: %w: %s", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !windows && !darwin
+
+package main
+
+import "fmt"
+
+// moveToTrash isn't implemented for this platform; TrashDeleter surfaces
+// this error and leaves the directory untouched rather than deleting it.
+func moveToTrash(path string, noCrossFS bool) error {
+	return fmt.Errorf("trash is not implemented on this platform")
+}
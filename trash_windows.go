@@ -0,0 +1,68 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// shFileOpStructW mirrors the Win32 SHFILEOPSTRUCTW layout used by
+// SHFileOperationW to send files to the recycle bin.
+type shFileOpStructW struct {
+	hwnd                  uintptr
+	wFunc                 uint32
+	pFrom                 *uint16
+	pTo                   *uint16
+	fFlags                uint16
+	fAnyOperationsAborted int32
+	hNameMappings         uintptr
+	lpszProgressTitle     *uint16
+}
+
+const (
+	foDelete     = 0x0003
+	fofAllowUndo = 0x0040
+	fofNoConfirm = 0x0010
+	fofNoErrorUI = 0x0400
+	fofSilent    = 0x0004
+)
+
+var (
+	shell32              = syscall.NewLazyDLL("shell32.dll")
+	procSHFileOperationW = shell32.NewProc("SHFileOperationW")
+)
+
+// moveToTrash sends path to the recycle bin via SHFileOperationW.
+// noCrossFS is unused: the recycle bin isn't tied to a single volume the
+// way the XDG trash on Linux is.
+func moveToTrash(path string, noCrossFS bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	// pFrom is a list of null-terminated strings, itself terminated by an
+	// extra null.
+	from, err := syscall.UTF16FromString(abs)
+	if err != nil {
+		return err
+	}
+	from = append(from, 0)
+
+	op := shFileOpStructW{
+		wFunc:  foDelete,
+		pFrom:  &from[0],
+		fFlags: fofAllowUndo | fofNoConfirm | fofNoErrorUI | fofSilent,
+	}
+	ret, _, _ := procSHFileOperationW.Call(uintptr(unsafe.Pointer(&op)))
+	if ret != 0 {
+		return fmt.Errorf("SHFileOperationW failed with code %d", ret)
+	}
+	if op.fAnyOperationsAborted != 0 {
+		return fmt.Errorf("recycle operation was aborted")
+	}
+	return nil
+}
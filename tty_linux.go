@@ -0,0 +1,43 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// rawState is the terminal state captured before entering raw mode, so it
+// can be restored afterwards.
+type rawState struct {
+	termios syscall.Termios
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
+
+// enableRawMode puts f into raw mode (no echo, no line buffering, signals
+// passed through as bytes) and returns the previous state for restoreMode.
+func enableRawMode(f *os.File) (*rawState, error) {
+	var oldState syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&oldState))); errno != 0 {
+		return nil, errno
+	}
+	newState := oldState
+	newState.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
+	newState.Iflag &^= syscall.IXON
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&newState))); errno != 0 {
+		return nil, errno
+	}
+	return &rawState{termios: oldState}, nil
+}
+
+// restoreMode restores a terminal state captured by enableRawMode.
+func restoreMode(f *os.File, state *rawState) {
+	syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&state.termios)))
+}
@@ -0,0 +1,22 @@
+//go:build !linux && !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Raw terminal mode is only implemented for Linux; on other Unixes the
+// interactive TUI is unavailable: runInteractiveSelect returns an error and
+// main falls back to the line-oriented prompt flow.
+
+type rawState struct{}
+
+func isTerminal(f *os.File) bool { return false }
+
+func enableRawMode(f *os.File) (*rawState, error) {
+	return nil, fmt.Errorf("raw terminal mode is not implemented on this platform")
+}
+
+func restoreMode(f *os.File, state *rawState) {}
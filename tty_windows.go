@@ -0,0 +1,22 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Raw terminal mode isn't implemented on Windows yet, so the interactive
+// TUI is unavailable there: runInteractiveSelect returns an error and main
+// falls back to the line-oriented prompt flow.
+
+type rawState struct{}
+
+func isTerminal(f *os.File) bool { return false }
+
+func enableRawMode(f *os.File) (*rawState, error) {
+	return nil, fmt.Errorf("raw terminal mode is not implemented on windows")
+}
+
+func restoreMode(f *os.File, state *rawState) {}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tuiRow is one line of the interactive multi-select list.
+type tuiRow struct {
+	fd       foundDir
+	selected bool
+	mtime    time.Time
+}
+
+// runInteractiveSelect drives a raw-terminal multi-select list over found,
+// with columns [x] type size age path. Space toggles the row under the
+// cursor, j/k or the arrow keys move, 'a' selects every row of the same
+// type as the cursor, 's' cycles the sort order, '/' filters by path
+// substring, and Enter/'d' confirms the selection. It returns an error if
+// stdin isn't a terminal or raw mode isn't supported, in which case the
+// caller should fall back to the line-oriented prompt flow.
+func runInteractiveSelect(found []foundDir) ([]foundDir, error) {
+	stdin := os.Stdin
+	if !isTerminal(stdin) {
+		return nil, fmt.Errorf("stdin is not a terminal")
+	}
+	oldState, err := enableRawMode(stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer restoreMode(stdin, oldState)
+
+	rows := make([]*tuiRow, len(found))
+	for i, fd := range found {
+		var mt time.Time
+		if info, err := os.Stat(fd.path); err == nil {
+			mt = info.ModTime()
+		}
+		rows[i] = &tuiRow{fd: fd, mtime: mt}
+	}
+
+	var (
+		cursor    int
+		filter    string
+		sortBy    string
+		filtering bool
+	)
+
+	visible := func() []*tuiRow {
+		if filter == "" {
+			return rows
+		}
+		var out []*tuiRow
+		for _, r := range rows {
+			if strings.Contains(strings.ToLower(r.fd.path), strings.ToLower(filter)) {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+
+	applySort := func() {
+		switch sortBy {
+		case "size":
+			sort.SliceStable(rows, func(i, j int) bool { return rows[i].fd.size > rows[j].fd.size })
+		case "age":
+			sort.SliceStable(rows, func(i, j int) bool { return rows[i].mtime.Before(rows[j].mtime) })
+		case "path":
+			sort.SliceStable(rows, func(i, j int) bool { return rows[i].fd.path < rows[j].fd.path })
+		}
+	}
+
+	render := func() {
+		vis := visible()
+		fmt.Print("\033[H\033[2J")
+		fmt.Print("space: toggle  a: select type  s: sort  /: filter  enter: delete selected  q: quit\r\n\r\n")
+		for i, r := range vis {
+			mark := " "
+			if r.selected {
+				mark = "x"
+			}
+			cursorMark := " "
+			if i == cursor {
+				cursorMark = ">"
+			}
+			age := "?"
+			if !r.mtime.IsZero() {
+				age = time.Since(r.mtime).Round(time.Hour).String()
+			}
+			fmt.Printf("%s [%s] %-8s %8s  %8s  %s\r\n", cursorMark, mark, r.fd.typ, humanSize(r.fd.size), age, r.fd.path)
+		}
+		if filtering {
+			fmt.Printf("\r\n/%s", filter)
+		}
+	}
+
+	buf := make([]byte, 3)
+	for {
+		render()
+
+		n, err := stdin.Read(buf)
+		if err != nil || n == 0 {
+			return nil, err
+		}
+
+		if filtering {
+			switch buf[0] {
+			case '\r', '\n':
+				filtering = false
+			case 127, '\b':
+				if len(filter) > 0 {
+					filter = filter[:len(filter)-1]
+				}
+			case 27:
+				filtering = false
+				filter = ""
+			default:
+				filter += string(buf[0])
+			}
+			cursor = 0
+			continue
+		}
+
+		vis := visible()
+		switch {
+		case buf[0] == 27 && n >= 3 && buf[1] == '[':
+			switch buf[2] {
+			case 'A':
+				if cursor > 0 {
+					cursor--
+				}
+			case 'B':
+				if cursor < len(vis)-1 {
+					cursor++
+				}
+			}
+		case buf[0] == 'k':
+			if cursor > 0 {
+				cursor--
+			}
+		case buf[0] == 'j':
+			if cursor < len(vis)-1 {
+				cursor++
+			}
+		case buf[0] == ' ':
+			if cursor < len(vis) {
+				vis[cursor].selected = !vis[cursor].selected
+			}
+		case buf[0] == 'a':
+			if cursor < len(vis) {
+				typ := vis[cursor].fd.typ
+				for _, r := range rows {
+					if r.fd.typ == typ {
+						r.selected = true
+					}
+				}
+			}
+		case buf[0] == 's':
+			switch sortBy {
+			case "":
+				sortBy = "size"
+			case "size":
+				sortBy = "age"
+			case "age":
+				sortBy = "path"
+			default:
+				sortBy = ""
+			}
+			applySort()
+			cursor = 0
+		case buf[0] == '/':
+			filtering = true
+			filter = ""
+		case buf[0] == 'd', buf[0] == '\r', buf[0] == '\n':
+			fmt.Print("\033[H\033[2J")
+			var selected []foundDir
+			for _, r := range rows {
+				if r.selected {
+					selected = append(selected, r.fd)
+				}
+			}
+			return selected, nil
+		case buf[0] == 'q', buf[0] == 3:
+			fmt.Print("\033[H\033[2J")
+			return nil, nil
+		}
+	}
+}